@@ -0,0 +1,128 @@
+// Package cache provides a persistent, on-disk cache for lyrics lookups, sitting between the CLI and
+// the provider chain. Re-running lyrics-fetch over a library it has already scanned becomes essentially
+// free, and tracks a provider doesn't have lyrics for are remembered (for a shorter TTL) so they aren't
+// re-requested on every run.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/wneessen/lyrics-fetch/providers"
+)
+
+// Fetcher is implemented by anything a Cache can wrap: the provider Chain, or another Fetcher.
+type Fetcher interface {
+	Fetch(ctx context.Context, artist, album, track string, duration time.Duration) (providers.Lyrics, error)
+}
+
+// entry is the on-disk, JSON-encoded representation of one cached lookup.
+type entry struct {
+	Lyrics    providers.Lyrics `json:"lyrics"`
+	Negative  bool             `json:"negative"`
+	ExpiresAt time.Time        `json:"expires_at"`
+}
+
+// Cache stores lyrics lookups as JSON files under dir, one file per cache key. Hits (tracks a provider
+// found lyrics for) and misses (tracks it definitively didn't) are kept under separate TTLs.
+type Cache struct {
+	dir         string
+	ttl         time.Duration
+	negativeTTL time.Duration
+}
+
+// New returns a Cache backed by dir, creating it if it doesn't exist yet.
+func New(dir string, ttl, negativeTTL time.Duration) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory %s: %w", dir, err)
+	}
+	return &Cache{dir: dir, ttl: ttl, negativeTTL: negativeTTL}, nil
+}
+
+// Wrap returns a Fetcher that consults the cache before falling through to next, and stores next's
+// result (hit or miss) for future lookups.
+func (c *Cache) Wrap(next Fetcher) Fetcher {
+	return &cachingFetcher{cache: c, next: next}
+}
+
+// key derives the cache key for a lookup. duration is rounded to the nearest second, matching the
+// granularity lyrics providers key their own lookups on, so that sub-second jitter in tag-reported
+// durations doesn't fragment the cache.
+func key(artist, album, track string, duration time.Duration) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%d", artist, album, track, int64(duration.Round(time.Second).Seconds()))))
+	return hex.EncodeToString(sum[:])
+}
+
+// path returns the on-disk path of the cache file for key.
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// load returns the cached entry for key, and false if there is none or it has expired.
+func (c *Cache) load(key string) (entry, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return entry{}, false
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return entry{}, false
+	}
+	if time.Now().After(e.ExpiresAt) {
+		return entry{}, false
+	}
+	return e, true
+}
+
+// store persists lyrics for key, as a negative entry (negativeTTL) when lyrics is empty or a positive
+// one (ttl) otherwise.
+func (c *Cache) store(key string, lyrics providers.Lyrics) error {
+	ttl := c.ttl
+	negative := lyrics.IsEmpty()
+	if negative {
+		ttl = c.negativeTTL
+	}
+	e := entry{Lyrics: lyrics, Negative: negative, ExpiresAt: time.Now().Add(ttl)}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+	if err := os.WriteFile(c.path(key), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+// cachingFetcher is the Fetcher returned by Cache.Wrap.
+type cachingFetcher struct {
+	cache *Cache
+	next  Fetcher
+}
+
+// Fetch returns the cached result for this lookup when one exists and hasn't expired, otherwise it
+// delegates to next and caches the result (hit or definitive miss) for next time.
+func (f *cachingFetcher) Fetch(ctx context.Context, artist, album, track string, duration time.Duration) (providers.Lyrics, error) {
+	k := key(artist, album, track, duration)
+	if e, ok := f.cache.load(k); ok {
+		if e.Negative {
+			return providers.Lyrics{}, nil
+		}
+		return e.Lyrics, nil
+	}
+
+	lyrics, err := f.next.Fetch(ctx, artist, album, track, duration)
+	if err != nil {
+		return lyrics, err
+	}
+	if err := f.cache.store(k, lyrics); err != nil {
+		return lyrics, fmt.Errorf("failed to cache lyrics lookup: %w", err)
+	}
+	return lyrics, nil
+}
@@ -0,0 +1,25 @@
+package cache
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseTTL parses a cache TTL string. It accepts anything time.ParseDuration does ("30m", "12h", ...)
+// plus a "d" (day) suffix, since TTLs like "-cache-ttl 30d" read far more naturally than "720h".
+func ParseTTL(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid TTL %q: %w", s, err)
+		}
+		return time.Duration(n * float64(24*time.Hour)), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid TTL %q: %w", s, err)
+	}
+	return d, nil
+}
@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "days", in: "30d", want: 30 * 24 * time.Hour},
+		{name: "fractional days", in: "0.5d", want: 12 * time.Hour},
+		{name: "stdlib hours", in: "12h", want: 12 * time.Hour},
+		{name: "stdlib minutes", in: "90m", want: 90 * time.Minute},
+		{name: "stdlib combined", in: "1h30m", want: 90 * time.Minute},
+		{name: "invalid day count", in: "xd", wantErr: true},
+		{name: "invalid stdlib duration", in: "30", wantErr: true},
+		{name: "empty", in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTTL(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseTTL(%q) = %v, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTTL(%q) returned unexpected error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTTL(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Package config loads lyrics-fetch's YAML configuration file, which supersedes the handful of flags
+// the CLI started out with: where to look for music, which lyrics agents to try and in what order,
+// concurrency, caching, per-provider settings, and how output is written. Flags still exist for quick
+// one-off overrides (see main.go), but the config file is the source of truth for everything else.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CacheConfig configures the on-disk lyrics lookup cache.
+type CacheConfig struct {
+	Disabled    bool   `yaml:"disabled"`
+	Dir         string `yaml:"dir"`
+	TTL         string `yaml:"ttl"`
+	NegativeTTL string `yaml:"negative_ttl"`
+}
+
+// LRCLIBConfig configures the built-in LRCLIB provider.
+type LRCLIBConfig struct {
+	Endpoint string        `yaml:"endpoint"`
+	Timeout  time.Duration `yaml:"timeout"`
+	Retries  int           `yaml:"retries"`
+}
+
+// AppleMusicConfig configures the built-in Apple Music provider.
+type AppleMusicConfig struct {
+	MediaUserTokenFile string `yaml:"media_user_token_file"`
+
+	// Enhanced switches the TTML->LRC conversion to emit Enhanced LRC word-level timing markers instead
+	// of concatenating each line's words into standard LRC.
+	Enhanced bool `yaml:"enhanced"`
+}
+
+// OutputConfig controls how fetched lyrics are written to disk.
+type OutputConfig struct {
+	// Format is one of "lrc" (sidecar file only), "embed" (tags only), or "both".
+	Format string `yaml:"format"`
+
+	// SidecarTemplate is a text/template string rendered with {{.Dir}} and {{.Base}} (the audio file's
+	// directory and its base name without extension) to produce the sidecar file's path.
+	SidecarTemplate string `yaml:"sidecar_template"`
+
+	// AllowPlain controls what happens when a provider only has unsynchronised (plain) lyrics: when
+	// false (the default) such results are treated as "no lyrics found"; when true, a degenerate LRC is
+	// synthesized from the plain text instead of skipping the track.
+	AllowPlain bool `yaml:"allow_plain"`
+}
+
+// Config is the root of config.yaml.
+type Config struct {
+	MusicDir    string           `yaml:"music_dir"`
+	Agents      []string         `yaml:"agents"`
+	Concurrency int              `yaml:"concurrency"`
+	Cache       CacheConfig      `yaml:"cache"`
+	LRCLIB      LRCLIBConfig     `yaml:"lrclib"`
+	AppleMusic  AppleMusicConfig `yaml:"applemusic"`
+	Output      OutputConfig     `yaml:"output"`
+	Extensions  []string         `yaml:"extensions"`
+}
+
+// Default returns the configuration used when no config.yaml is found and no flag overrides it; it
+// matches the hardcoded defaults this tool shipped with before the config file existed.
+func Default() *Config {
+	return &Config{
+		Agents:      []string{"lrclib"},
+		Concurrency: 4,
+		Cache: CacheConfig{
+			TTL:         "30d",
+			NegativeTTL: "7d",
+		},
+		LRCLIB: LRCLIBConfig{
+			Endpoint: "https://lrclib.net/api/get",
+			Timeout:  30 * time.Second,
+			Retries:  3,
+		},
+		Output: OutputConfig{
+			Format:          "lrc",
+			SidecarTemplate: "{{.Dir}}/{{.Base}}.lrc",
+		},
+		Extensions: []string{".mp3", ".flac", ".aac", ".ogg", ".dsd", ".dsf", ".mp4"},
+	}
+}
+
+// Find resolves the config file path to load: override if non-empty, otherwise
+// $XDG_CONFIG_HOME/lyrics-fetch/config.yaml, or os.UserConfigDir()/lyrics-fetch/config.yaml when
+// XDG_CONFIG_HOME is unset. It returns "" (and no error) when that file doesn't exist, which tells Load
+// to fall back to Default().
+func Find(override string) (string, error) {
+	if override != "" {
+		return override, nil
+	}
+
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		userConfig, err := os.UserConfigDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to determine config directory: %w", err)
+		}
+		dir = userConfig
+	}
+
+	path := filepath.Join(dir, "lyrics-fetch", "config.yaml")
+	if _, err := os.Stat(path); err != nil {
+		return "", nil
+	}
+	return path, nil
+}
+
+// Load reads the config file at path into a Default()-initialized Config, so that any field the file
+// doesn't set keeps its default value. An empty path returns Default() unchanged.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// YAML renders the effective configuration back to YAML, for the --print-config flag.
+func (c *Config) YAML() (string, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return string(data), nil
+}
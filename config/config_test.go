@@ -0,0 +1,64 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadEmptyPathReturnsDefault(t *testing.T) {
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load(\"\") returned unexpected error: %v", err)
+	}
+	want := Default()
+	if cfg.Concurrency != want.Concurrency || cfg.Cache.TTL != want.Cache.TTL {
+		t.Errorf("Load(\"\") = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestLoadMergesOntoDefaults(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	const yamlDoc = `
+music_dir: /music
+concurrency: 8
+cache:
+  ttl: 1d
+`
+	if err := os.WriteFile(path, []byte(yamlDoc), 0o644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load(%q) returned unexpected error: %v", path, err)
+	}
+
+	if cfg.MusicDir != "/music" {
+		t.Errorf("MusicDir = %q, want %q", cfg.MusicDir, "/music")
+	}
+	if cfg.Concurrency != 8 {
+		t.Errorf("Concurrency = %d, want 8", cfg.Concurrency)
+	}
+	if cfg.Cache.TTL != "1d" {
+		t.Errorf("Cache.TTL = %q, want %q", cfg.Cache.TTL, "1d")
+	}
+	// Fields the file didn't set should keep their Default() value.
+	if cfg.Cache.NegativeTTL != "7d" {
+		t.Errorf("Cache.NegativeTTL = %q, want default %q", cfg.Cache.NegativeTTL, "7d")
+	}
+	if len(cfg.Agents) != 1 || cfg.Agents[0] != "lrclib" {
+		t.Errorf("Agents = %v, want default [lrclib]", cfg.Agents)
+	}
+	if cfg.LRCLIB.Timeout != 30*time.Second {
+		t.Errorf("LRCLIB.Timeout = %v, want default 30s", cfg.LRCLIB.Timeout)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatal("Load() of a missing file = nil error, want error")
+	}
+}
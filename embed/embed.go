@@ -0,0 +1,88 @@
+// Package embed writes fetched lyrics directly into an audio file's own metadata, as an alternative (or
+// addition) to the ".lrc" sidecar file lyrics-fetch writes by default. dhowden/tag, used elsewhere in
+// this repo for reading tags, is read-only, so writing uses format-specific tag-writing libraries
+// instead: bogem/id3v2 for ID3v2 (MP3/AAC), and go.senan.dev/taglib's generic property API for FLAC,
+// OGG and MP4.
+//
+// Known limitation: bogem/id3v2/v2 has no SYLT (synchronised lyrics) frame support, so MP3/AAC never
+// get time-synchronised lyrics embedded in their tags, only the plain/stripped-timestamp fallback (USLT).
+// FLAC/OGG/MP4, via TagLib's UNSYNCEDLYRICS property, don't have this limitation.
+package embed
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/wneessen/lyrics-fetch/providers"
+)
+
+// Mode controls how Write persists fetched lyrics to disk, matching the -embed/-embed-only flags.
+type Mode int
+
+const (
+	// ModeSidecar writes only the ".lrc" sidecar file (the pre-existing, default behavior).
+	ModeSidecar Mode = iota
+
+	// ModeEmbed writes both the ".lrc" sidecar and the audio file's tags.
+	ModeEmbed
+
+	// ModeEmbedOnly writes the lyrics into the audio file's tags and skips the sidecar entirely.
+	ModeEmbedOnly
+)
+
+// WritesSidecar reports whether m calls for a ".lrc" sidecar file to be written.
+func (m Mode) WritesSidecar() bool { return m == ModeSidecar || m == ModeEmbed }
+
+// WritesTags reports whether m calls for lyrics to be embedded into the audio file's own tags.
+func (m Mode) WritesTags() bool { return m == ModeEmbed || m == ModeEmbedOnly }
+
+// ModeFromString converts config.yaml's output.format ("lrc", "embed", "both") into a Mode. An empty
+// string is treated as "lrc", matching the format field's default.
+func ModeFromString(format string) (Mode, error) {
+	switch format {
+	case "", "lrc":
+		return ModeSidecar, nil
+	case "embed":
+		return ModeEmbedOnly, nil
+	case "both":
+		return ModeEmbed, nil
+	default:
+		return 0, fmt.Errorf("embed: unknown output format %q (want lrc, embed or both)", format)
+	}
+}
+
+// Write embeds lyrics into path's audio tags, dispatching on the file extension. It writes an
+// unsynchronised (plain) form always, and a synchronised form as well when lyrics.Synced is set and the
+// format supports it.
+func Write(path string, lyrics providers.Lyrics) error {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".mp3", ".aac":
+		return writeID3(path, lyrics)
+	case ".flac":
+		return writeFLAC(path, lyrics)
+	case ".ogg":
+		return writeVorbisComment(path, lyrics)
+	case ".mp4":
+		return writeMP4(path, lyrics)
+	default:
+		return fmt.Errorf("embed: unsupported format %q", ext)
+	}
+}
+
+// HasLyrics reports whether the audio file at path already carries embedded lyrics, so callers can
+// treat it the same as a pre-existing ".lrc" sidecar when deciding whether to skip a file.
+func HasLyrics(path string) (bool, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".mp3", ".aac":
+		return hasID3Lyrics(path)
+	case ".flac":
+		return hasFLACLyrics(path)
+	case ".ogg":
+		return hasVorbisLyrics(path)
+	case ".mp4":
+		return hasMP4Lyrics(path)
+	default:
+		return false, nil
+	}
+}
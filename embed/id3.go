@@ -0,0 +1,45 @@
+package embed
+
+import (
+	"fmt"
+
+	"github.com/bogem/id3v2/v2"
+	"github.com/wneessen/lyrics-fetch/providers"
+)
+
+// writeID3 writes an ID3v2 USLT (unsynchronised lyrics) frame into path's ID3v2 tag. MP3 and AAC files
+// both use ID3v2. bogem/id3v2/v2 has no SYLT (synchronised lyrics) frame support, so when only timed
+// lyrics were fetched they're written as plain text with their timestamps stripped, same as the USLT
+// fallback used elsewhere in this package.
+func writeID3(path string, lyrics providers.Lyrics) error {
+	tag, err := id3v2.Open(path, id3v2.Options{Parse: true})
+	if err != nil {
+		return fmt.Errorf("failed to open %s for ID3 tag writing: %w", path, err)
+	}
+	defer func() { _ = tag.Close() }()
+
+	plain := lyrics.Plain
+	if plain == "" {
+		plain = stripLRCTimestamps(lyrics.Synced)
+	}
+	tag.AddUnsynchronisedLyricsFrame(id3v2.UnsynchronisedLyricsFrame{
+		Encoding: id3v2.EncodingUTF8,
+		Language: "eng",
+		Lyrics:   plain,
+	})
+
+	if err := tag.Save(); err != nil {
+		return fmt.Errorf("failed to save ID3 tags for %s: %w", path, err)
+	}
+	return nil
+}
+
+// hasID3Lyrics reports whether path already carries a USLT frame.
+func hasID3Lyrics(path string) (bool, error) {
+	tag, err := id3v2.Open(path, id3v2.Options{Parse: true})
+	if err != nil {
+		return false, fmt.Errorf("failed to open %s for ID3 tag reading: %w", path, err)
+	}
+	defer func() { _ = tag.Close() }()
+	return len(tag.GetFrames(tag.CommonID("Unsynchronised lyrics/text transcription"))) > 0, nil
+}
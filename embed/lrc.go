@@ -0,0 +1,61 @@
+package embed
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// lrcLinePattern matches a single LRC line's leading "[mm:ss.xx]" timestamp tag.
+var lrcLinePattern = regexp.MustCompile(`^\[(\d+):(\d{2})\.(\d{2})\](.*)$`)
+
+// lrcLine is one timed line of an LRC lyrics file.
+type lrcLine struct {
+	Time time.Duration
+	Text string
+}
+
+// parseLRC splits LRC-formatted text into its timed lines. Lines without a recognised "[mm:ss.xx]" tag
+// are skipped rather than rejected outright, since stray metadata tags (e.g. "[ar:]", "[ti:]") are
+// common in the wild.
+func parseLRC(lrc string) ([]lrcLine, error) {
+	var lines []lrcLine
+	for _, raw := range strings.Split(lrc, "\n") {
+		match := lrcLinePattern.FindStringSubmatch(raw)
+		if match == nil {
+			continue
+		}
+		minutes, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid LRC minutes in line %q: %w", raw, err)
+		}
+		seconds, err := strconv.Atoi(match[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid LRC seconds in line %q: %w", raw, err)
+		}
+		centiseconds, err := strconv.Atoi(match[3])
+		if err != nil {
+			return nil, fmt.Errorf("invalid LRC centiseconds in line %q: %w", raw, err)
+		}
+		at := time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second +
+			time.Duration(centiseconds)*10*time.Millisecond
+		lines = append(lines, lrcLine{Time: at, Text: strings.TrimSpace(match[4])})
+	}
+	return lines, nil
+}
+
+// stripLRCTimestamps returns the plain-text lyrics underneath an LRC document, used as the USLT/Vorbis
+// "plain" fallback when only synced lyrics were fetched.
+func stripLRCTimestamps(lrc string) string {
+	lines, err := parseLRC(lrc)
+	if err != nil {
+		return lrc
+	}
+	text := make([]string, 0, len(lines))
+	for _, line := range lines {
+		text = append(text, line.Text)
+	}
+	return strings.Join(text, "\n")
+}
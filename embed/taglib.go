@@ -0,0 +1,73 @@
+package embed
+
+import (
+	"fmt"
+
+	"github.com/wneessen/lyrics-fetch/providers"
+	"go.senan.dev/taglib"
+)
+
+// taglibPlainField and taglibSyncedField are the generic TagLib property keys used for lyrics. TagLib maps them to the
+// Vorbis comment fields LYRICS/UNSYNCEDLYRICS for FLAC and OGG, and to the "\xa9lyr" atom for MP4.
+const (
+	taglibPlainField  = "LYRICS"
+	taglibSyncedField = "UNSYNCEDLYRICS"
+)
+
+// writeFLAC writes lyrics into a FLAC file's Vorbis comment block via TagLib's generic property API.
+func writeFLAC(path string, lyrics providers.Lyrics) error {
+	return writeTaglibLyrics(path, lyrics)
+}
+
+// writeVorbisComment writes lyrics into an OGG file's Vorbis comment block via TagLib's generic
+// property API.
+func writeVorbisComment(path string, lyrics providers.Lyrics) error {
+	return writeTaglibLyrics(path, lyrics)
+}
+
+// writeMP4 writes lyrics into an MP4/M4A file's "\xa9lyr" atom via TagLib's generic property API.
+func writeMP4(path string, lyrics providers.Lyrics) error {
+	return writeTaglibLyrics(path, lyrics)
+}
+
+// writeTaglibLyrics writes lyrics into path via TagLib's generic property map, shared by every format
+// TagLib itself knows how to tag (FLAC, OGG, MP4). The plain field always gets the best text we have;
+// the synced (LRC) field is only set when timed lyrics were actually fetched.
+func writeTaglibLyrics(path string, lyrics providers.Lyrics) error {
+	tags := map[string][]string{taglibPlainField: {plainLyrics(lyrics)}}
+	if lyrics.Synced != "" {
+		tags[taglibSyncedField] = []string{lyrics.Synced}
+	}
+	if err := taglib.WriteTags(path, tags, 0); err != nil {
+		return fmt.Errorf("failed to write TagLib lyrics tags for %s: %w", path, err)
+	}
+	return nil
+}
+
+// hasFLACLyrics reports whether path's Vorbis comment block already has a LYRICS field.
+func hasFLACLyrics(path string) (bool, error) { return hasTaglibLyrics(path) }
+
+// hasVorbisLyrics reports whether path's Vorbis comment block already has a LYRICS field.
+func hasVorbisLyrics(path string) (bool, error) { return hasTaglibLyrics(path) }
+
+// hasMP4Lyrics reports whether path's "\xa9lyr" atom is already set.
+func hasMP4Lyrics(path string) (bool, error) { return hasTaglibLyrics(path) }
+
+// hasTaglibLyrics reports whether path already has a non-empty LYRICS property.
+func hasTaglibLyrics(path string) (bool, error) {
+	tags, err := taglib.ReadTags(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read TagLib tags for %s: %w", path, err)
+	}
+	values, ok := tags[taglibPlainField]
+	return ok && len(values) > 0 && values[0] != "", nil
+}
+
+// plainLyrics returns the best plain-text representation of lyrics, falling back to stripping the
+// timestamps off the synced form when no plain text was fetched.
+func plainLyrics(lyrics providers.Lyrics) string {
+	if lyrics.Plain != "" {
+		return lyrics.Plain
+	}
+	return stripLRCTimestamps(lyrics.Synced)
+}
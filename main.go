@@ -7,59 +7,51 @@ import (
 	"fmt"
 	"io/fs"
 	"log/slog"
-	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"sync/atomic"
+	"text/template"
 	"time"
 
 	"github.com/dhowden/tag"
 	"github.com/hcl/audioduration"
+	"github.com/wneessen/lyrics-fetch/cache"
+	"github.com/wneessen/lyrics-fetch/config"
+	"github.com/wneessen/lyrics-fetch/embed"
+	"github.com/wneessen/lyrics-fetch/providers"
 )
 
+// progressInterval controls how often the processed/total progress line is logged.
+const progressInterval = 5 * time.Second
+
+// lyricsFetcher is implemented by the provider Chain and, when caching is enabled, by the Fetcher that
+// cache.Cache.Wrap puts in front of it; fetcher only needs to know it can Fetch, not which is in front of the other.
+type lyricsFetcher interface {
+	Fetch(ctx context.Context, artist, album, track string, duration time.Duration) (providers.Lyrics, error)
+}
+
 // fetcher is a type used for fetching song lyrics, logging errors, and managing HTTP requests through a custom client.
 type fetcher struct {
 	errLog *slog.Logger
 	stdLog *slog.Logger
-	client *Client
-}
+	chain  lyricsFetcher
+	mode   embed.Mode
 
-// apiResponse represents the structure of the API response containing song and lyrics-related metadata
-// of the LRCLIB API
-type apiResponse struct {
-	ID           int     `json:"id"`
-	TrackName    string  `json:"trackName"`
-	ArtistName   string  `json:"artistName"`
-	AlbumName    string  `json:"albumName"`
-	Duration     float64 `json:"duration"`
-	Instrumental bool    `json:"instrumental"`
-	PlainLyrics  string  `json:"plainLyrics"`
-	SyncedLyrics string  `json:"syncedLyrics"`
-}
-
-const (
+	// allowPlain controls whether plain-only lyrics are synthesized into a degenerate LRC instead of
+	// being treated as "no lyrics found", as configured by output.allow_plain.
+	allowPlain bool
 
-	// apiEndpoint represents the base URL of the LRCLIB API used for fetching song lyrics.
-	apiEndpoint = "https://lrclib.net/api/get"
+	// extensions holds the (lowercased) supported audio file extensions, as configured by extensions.
+	extensions map[string]bool
 
-	// apiTimeout defines the maximum duration for API requests to prevent indefinite hanging of HTTP calls.
-	apiTimeout = time.Second * 30
-)
+	// sidecar renders the ".lrc" sidecar path for a given audio file, as configured by
+	// output.sidecar_template.
+	sidecar *template.Template
+}
 
 var (
-	// extensions is a map that defines supported file extensions for audio files, with each entry indicating
-	//its validity.
-	extensions = map[string]bool{
-		".mp3":  true,
-		".flac": true,
-		".aac":  true,
-		".ogg":  true,
-		".dsd":  true,
-		".dsf":  true,
-		".mp4":  true,
-	}
-
 	// fetchedCount tracks the number of files successfully processed and lyrics retrieved.
 	fetchedCount atomic.Uint64
 
@@ -73,12 +65,88 @@ var (
 
 func main() {
 	var musicDir string
+	var agents string
 	var debug bool
+	var embedLyrics bool
+	var embedOnly bool
+	var allowPlain bool
+	var enhanced bool
+	var concurrency int
+	var noCache bool
+	var cacheDir string
+	var cacheTTL string
+	var negativeCacheTTL string
+	var configPath string
+	var printConfig bool
 	flag.StringVar(&musicDir, "i", "", "root directory for music files")
+	flag.StringVar(&agents, "agents", "", "comma-separated, ordered list of lyrics providers to try (overrides config.yaml)")
 	flag.BoolVar(&debug, "d", false, "enable debug logging")
+	flag.BoolVar(&embedLyrics, "embed", false, "also embed lyrics into the audio file's own tags")
+	flag.BoolVar(&embedOnly, "embed-only", false, "embed lyrics into the audio file's own tags instead of writing a .lrc sidecar")
+	flag.BoolVar(&allowPlain, "allow-plain", false, "synthesize a degenerate LRC from plain lyrics instead of skipping when no synced lyrics are found")
+	flag.BoolVar(&enhanced, "enhanced", false, "emit Enhanced LRC word-level timing markers for providers that support it (overrides config.yaml)")
+	flag.IntVar(&concurrency, "concurrency", 0, "number of files to process concurrently (overrides config.yaml)")
+	flag.BoolVar(&noCache, "no-cache", false, "disable the on-disk lyrics lookup cache")
+	flag.StringVar(&cacheDir, "cache-dir", "", "directory for the on-disk lyrics lookup cache (default $XDG_CACHE_HOME/lyrics-fetch)")
+	flag.StringVar(&cacheTTL, "cache-ttl", "", "how long successful lyrics lookups are cached for (overrides config.yaml)")
+	flag.StringVar(&negativeCacheTTL, "negative-cache-ttl", "", "how long \"no lyrics found\" lookups are cached for (overrides config.yaml)")
+	flag.StringVar(&configPath, "config", "", "path to config.yaml (default $XDG_CONFIG_HOME/lyrics-fetch/config.yaml)")
+	flag.BoolVar(&printConfig, "print-config", false, "print the effective configuration as YAML and exit")
 	flag.Parse()
 
-	if musicDir == "" {
+	path, err := config.Find(configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "i":
+			cfg.MusicDir = musicDir
+		case "agents":
+			cfg.Agents = strings.Split(agents, ",")
+		case "concurrency":
+			cfg.Concurrency = concurrency
+		case "no-cache":
+			cfg.Cache.Disabled = noCache
+		case "cache-dir":
+			cfg.Cache.Dir = cacheDir
+		case "cache-ttl":
+			cfg.Cache.TTL = cacheTTL
+		case "negative-cache-ttl":
+			cfg.Cache.NegativeTTL = negativeCacheTTL
+		case "embed-only":
+			if embedOnly {
+				cfg.Output.Format = "embed"
+			}
+		case "embed":
+			if embedLyrics {
+				cfg.Output.Format = "both"
+			}
+		case "allow-plain":
+			cfg.Output.AllowPlain = allowPlain
+		case "enhanced":
+			cfg.AppleMusic.Enhanced = enhanced
+		}
+	})
+
+	if printConfig {
+		yml, err := cfg.YAML()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Print(yml)
+		os.Exit(0)
+	}
+
+	if cfg.MusicDir == "" {
 		flag.PrintDefaults()
 		os.Exit(1)
 	}
@@ -87,38 +155,182 @@ func main() {
 	if debug {
 		stdLevel = slog.LevelDebug
 	}
+	var chain lyricsFetcher
+	chain, err = providers.NewChain(cfg.Agents, cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if !cfg.Cache.Disabled {
+		chain, err = withCache(chain, cfg.Cache)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	mode, err := embed.ModeFromString(cfg.Output.Format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	sidecar, err := template.New("sidecar").Parse(cfg.Output.SidecarTemplate)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid output.sidecar_template: %s\n", err)
+		os.Exit(1)
+	}
+	extensions := make(map[string]bool, len(cfg.Extensions))
+	for _, ext := range cfg.Extensions {
+		extensions[strings.ToLower(ext)] = true
+	}
+	if cfg.Concurrency < 1 {
+		fmt.Fprintf(os.Stderr, "concurrency must be at least 1, got %d\n", cfg.Concurrency)
+		os.Exit(1)
+	}
 	fetch := &fetcher{
-		client: New(),
-		errLog: slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})),
-		stdLog: slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: stdLevel})),
+		chain:      chain,
+		mode:       mode,
+		allowPlain: cfg.Output.AllowPlain,
+		extensions: extensions,
+		sidecar:    sidecar,
+		errLog:     slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})),
+		stdLog:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: stdLevel})),
 	}
 
-	fetch.stdLog.Info("starting music lyrics fetcher", slog.String("music_dir", musicDir))
-	if err := filepath.WalkDir(musicDir, fetch.findFiles); err != nil {
+	fetch.stdLog.Info("starting music lyrics fetcher", slog.String("music_dir", cfg.MusicDir),
+		slog.String("agents", strings.Join(cfg.Agents, ",")), slog.Int("concurrency", cfg.Concurrency))
+	if err := fetch.run(cfg.MusicDir, cfg.Concurrency); err != nil {
 		fetch.errLog.Error("failed to process music files", logErr(err))
 	}
 	fetch.stdLog.Info("finished music lyrics fetcher", slog.Uint64("successfully_fetched", fetchedCount.Load()),
 		slog.Uint64("files_skipped", skippedCount.Load()), slog.Uint64("errors", errCount.Load()))
 }
 
-// logErr converts an error into a slog.Attr to use as a structured logging attribute.
-func logErr(err error) slog.Attr {
-	return slog.Any("error", err)
+// run walks root for audio files and processes them with a pool of concurrency worker goroutines,
+// logging a processed/total progress line every progressInterval until the walk and all workers finish.
+func (f *fetcher) run(root string, concurrency int) error {
+	total, err := f.countFiles(root)
+	if err != nil {
+		return fmt.Errorf("failed to count music files: %w", err)
+	}
+
+	paths := make(chan string)
+	stopProgress := f.logProgress(total)
+	defer stopProgress()
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range paths {
+				f.handleFile(path)
+			}
+		}()
+	}
+
+	walkErr := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to walk directory: %w", err)
+		}
+		if entry.IsDir() || !f.extensions[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+		paths <- path
+		return nil
+	})
+	close(paths)
+	wg.Wait()
+
+	return walkErr
 }
 
-// findFiles processes files in a directory, determines if they should be skipped, and handles them if applicable.
-func (f *fetcher) findFiles(path string, entry fs.DirEntry, err error) error {
+// countFiles returns the number of files under root whose extension we support, used as the "total" in
+// the processed/total progress line. It does not apply skipFile's existing-lyrics check, so the count
+// is an upper bound on the files that will actually be fetched.
+func (f *fetcher) countFiles(root string) (uint64, error) {
+	var total uint64
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to walk directory: %w", err)
+		}
+		if !entry.IsDir() && f.extensions[strings.ToLower(filepath.Ext(path))] {
+			total++
+		}
+		return nil
+	})
+	return total, err
+}
+
+// logProgress starts a ticker that logs how many files have been processed out of total every
+// progressInterval, and returns a function that stops it.
+func (f *fetcher) logProgress(total uint64) func() {
+	ticker := time.NewTicker(progressInterval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				processed := fetchedCount.Load() + skippedCount.Load() + errCount.Load()
+				f.stdLog.Info("progress", slog.Uint64("processed", processed), slog.Uint64("total", total))
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		ticker.Stop()
+		close(done)
+	}
+}
+
+// withCache wraps chain in a cache.Cache backed by cfg.Dir (or $XDG_CACHE_HOME/lyrics-fetch when Dir is
+// empty), using cfg.TTL and cfg.NegativeTTL as the cache and negative-cache lifetimes.
+func withCache(chain lyricsFetcher, cfg config.CacheConfig) (lyricsFetcher, error) {
+	dir := cfg.Dir
+	if dir == "" {
+		base := os.Getenv("XDG_CACHE_HOME")
+		if base == "" {
+			userCache, err := os.UserCacheDir()
+			if err != nil {
+				return nil, fmt.Errorf("failed to determine cache directory: %w", err)
+			}
+			base = userCache
+		}
+		dir = filepath.Join(base, "lyrics-fetch")
+	}
+
+	positiveTTL, err := cache.ParseTTL(cfg.TTL)
 	if err != nil {
-		return fmt.Errorf("failed to walk directory: %w", err)
+		return nil, fmt.Errorf("invalid cache.ttl: %w", err)
 	}
+	negTTL, err := cache.ParseTTL(cfg.NegativeTTL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache.negative_ttl: %w", err)
+	}
+
+	c, err := cache.New(dir, positiveTTL, negTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize lyrics cache: %w", err)
+	}
+	return c.Wrap(chain), nil
+}
+
+// logErr converts an error into a slog.Attr to use as a structured logging attribute.
+func logErr(err error) slog.Attr {
+	return slog.Any("error", err)
+}
 
-	skip, outfile := f.skipFile(path, entry)
+// handleFile determines if path should be skipped and, if not, processes it.
+func (f *fetcher) handleFile(path string) {
+	skip, outfile := f.skipFile(path)
 	if skip {
 		skippedCount.Add(1)
-		return nil
+		return
 	}
 
-	return f.processFile(path, outfile)
+	if err := f.processFile(path, outfile); err != nil {
+		f.errLog.Error("failed to process file", logErr(err), slog.String("file", path))
+	}
 }
 
 // processFile processes the given file, extracts metadata, retrieves lyrics, and writes them to the specified
@@ -148,7 +360,8 @@ func (f *fetcher) processFile(path, outfile string) error {
 	f.stdLog.Debug("processing song", slog.String("file", path),
 		slog.String("artist", data.Artist()), slog.String("album", data.Album()),
 		slog.String("title", data.Title()), slog.String("duration", duration.String()))
-	lyrics, err := f.retrieveLyrics(data.Artist(), data.Album(), data.Title(), duration)
+	ctx := providers.WithSourcePath(context.Background(), path)
+	lyrics, err := f.chain.Fetch(ctx, data.Artist(), data.Album(), data.Title(), duration)
 	if err != nil {
 		f.errLog.Error("failed to retrieve lyrics", logErr(err), slog.String("file", path),
 			slog.String("artist", data.Artist()), slog.String("album", data.Album()),
@@ -156,29 +369,65 @@ func (f *fetcher) processFile(path, outfile string) error {
 		errCount.Add(1)
 		return nil
 	}
-
-	output, err := os.Create(outfile)
-	if err != nil {
-		f.errLog.Error("failed to create output file", logErr(err), slog.String("file", outfile))
-		errCount.Add(1)
+	if lyrics.IsEmpty() {
+		f.stdLog.Warn("no lyrics found for song, skipping", slog.String("file", path),
+			slog.String("artist", data.Artist()), slog.String("album", data.Album()),
+			slog.String("title", data.Title()))
+		skippedCount.Add(1)
 		return nil
 	}
-	defer func() { _ = output.Close() }()
+	if !lyrics.HasSynced() {
+		if !f.allowPlain {
+			f.stdLog.Warn("only plain lyrics found for song, skipping (enable -allow-plain to use them)",
+				slog.String("file", path), slog.String("artist", data.Artist()),
+				slog.String("album", data.Album()), slog.String("title", data.Title()))
+			skippedCount.Add(1)
+			return nil
+		}
+		lyrics.Synced = providers.SynthesizeLRC(lyrics.Plain, duration)
+	}
 
-	_, err = output.WriteString(lyrics)
-	if err != nil {
-		f.errLog.Error("failed to write lyrics to output file", logErr(err), slog.String("file", outfile))
-		errCount.Add(1)
-		return nil
+	if f.mode.WritesSidecar() {
+		if err := f.writeSidecar(outfile, lyrics); err != nil {
+			f.errLog.Error("failed to write lyrics to output file", logErr(err), slog.String("file", outfile))
+			errCount.Add(1)
+			return nil
+		}
+	}
+	if f.mode.WritesTags() {
+		if err := embed.Write(path, lyrics); err != nil {
+			f.errLog.Error("failed to embed lyrics into audio tags", logErr(err), slog.String("file", path))
+			errCount.Add(1)
+			return nil
+		}
 	}
 
-	f.stdLog.Debug("wrote lyrics to output file", slog.String("file", outfile),
+	f.stdLog.Debug("wrote lyrics", slog.String("file", path), slog.String("source", lyrics.Source),
 		slog.String("artist", data.Artist()), slog.String("album", data.Album()),
 		slog.String("title", data.Title()), slog.String("duration", duration.String()))
 	fetchedCount.Add(1)
 	return nil
 }
 
+// writeSidecar writes lyrics as plain text to the ".lrc" sidecar file at outfile, preferring the synced
+// form when available.
+func (f *fetcher) writeSidecar(outfile string, lyrics providers.Lyrics) error {
+	output, err := os.Create(outfile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer func() { _ = output.Close() }()
+
+	text := lyrics.Synced
+	if text == "" {
+		text = lyrics.Plain
+	}
+	if _, err := output.WriteString(text); err != nil {
+		return fmt.Errorf("failed to write lyrics to output file: %w", err)
+	}
+	return nil
+}
+
 // songDuration extracts the duration of an audio file based on its format and returns it as a
 // time.Duration value.
 func (f *fetcher) songDuration(file *os.File, format string) (time.Duration, error) {
@@ -203,63 +452,49 @@ func (f *fetcher) songDuration(file *os.File, format string) (time.Duration, err
 	return time.Second * time.Duration(dur), err
 }
 
-// skipFile determines if a file should be skipped based on its type, extension, or existence of a lyrics file.
-func (f *fetcher) skipFile(path string, entry fs.DirEntry) (bool, string) {
+// skipFile determines if a file should be skipped based on its extension or the existence of a lyrics
+// file (either a sidecar rendered from output.sidecar_template, or lyrics already embedded in the audio
+// file's own tags).
+func (f *fetcher) skipFile(path string) (bool, string) {
 	ext := filepath.Ext(path)
 
-	// We don't want to process directories or non-supported extensions
-	if entry.IsDir() || !extensions[strings.ToLower(ext)] {
+	// We don't want to process non-supported extensions
+	if !f.extensions[strings.ToLower(ext)] {
+		return true, ""
+	}
+
+	outfile, err := f.sidecarPath(path)
+	if err != nil {
+		f.errLog.Warn("failed to render sidecar path, skipping", logErr(err), slog.String("file", path))
 		return true, ""
 	}
 
 	// Skip file if a lyrics file already exists
-	dir, _ := filepath.Split(path)
-	basefile := filepath.Base(path)
-	filen := basefile[:len(basefile)-len(ext)] + ".lrc"
-	if _, err := os.Stat(filepath.Join(dir, filen)); err == nil {
+	if _, err := os.Stat(outfile); err == nil {
 		f.errLog.Warn("lyrics file already exists for file, skipping retrival", slog.String("file", path))
 		return true, ""
 	}
 
-	return false, filepath.Join(dir, filen)
+	if has, err := embed.HasLyrics(path); err != nil {
+		f.errLog.Warn("failed to check for embedded lyrics, continuing anyway", logErr(err), slog.String("file", path))
+	} else if has {
+		f.errLog.Warn("lyrics already embedded in file, skipping retrival", slog.String("file", path))
+		return true, ""
+	}
+
+	return false, outfile
 }
 
-// retrieveLyrics fetches lyrics for a specific song by artist, album, and track, with retries on failure.
-// Returns the synchronized lyrics if available or an error if the lyrics could not be fetched.
-func (f *fetcher) retrieveLyrics(artist, album, track string, duration time.Duration) (string, error) {
-	query := url.Values{}
-	query.Set("track_name", track)
-	query.Set("artist_name", artist)
-	query.Set("album_name", album)
-	query.Set("duration", fmt.Sprintf("%.0f", duration.Seconds()))
-
-	retries := 3
-	res := new(apiResponse)
-	for i := 0; i < retries; i++ {
-		retCode, err := f.client.GetWithTimeout(context.Background(), apiEndpoint, res, query, nil, apiTimeout)
-		if err != nil {
-			switch {
-			case retCode == 404:
-				return "", fmt.Errorf("no lyrics found for song '%s - %s (%s)'", artist, track, album)
-			default:
-				f.errLog.Error("failed to retrieve lyrics from LRCLIB API", logErr(err))
-
-				// We'll sleep for a second before retrying
-				f.stdLog.Debug("retrying in 1 second", slog.Int("retry", i+1),
-					slog.Int("retries", retries))
-				time.Sleep(time.Second)
-				continue
-			}
-		}
-		if res.Instrumental {
-			f.stdLog.Warn("song is an instrumental, writing empty lyrics file", slog.String("artist", artist),
-				slog.String("album", album), slog.String("title", track),
-				slog.String("duration", duration.String()))
-			return "", nil
-		}
-		if res.SyncedLyrics != "" {
-			return res.SyncedLyrics, nil
-		}
+// sidecarPath renders f.sidecar with the audio file's directory and base name (without extension) to
+// produce the ".lrc" sidecar path for path.
+func (f *fetcher) sidecarPath(path string) (string, error) {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(filepath.Base(path), ext)
+
+	var buf strings.Builder
+	data := struct{ Dir, Base string }{Dir: filepath.Dir(path), Base: base}
+	if err := f.sidecar.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render sidecar template: %w", err)
 	}
-	return "", fmt.Errorf("failed to retrieve lyrics from LRCLIB API after %d retries", retries)
+	return buf.String(), nil
 }
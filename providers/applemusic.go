@@ -0,0 +1,259 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dhowden/tag"
+	"github.com/wneessen/lyrics-fetch/config"
+)
+
+const (
+	// appleMusicAPI is the base URL of the Apple Music catalog API.
+	appleMusicAPI = "https://amp-api.music.apple.com/v1/catalog"
+
+	// appleMusicTimeout bounds every request made against the Apple Music API.
+	appleMusicTimeout = time.Second * 30
+
+	// appleMusicDefaultStorefront is used when no storefront has been resolved for a song yet.
+	appleMusicDefaultStorefront = "us"
+
+	// tokenEnvVar holds the media-user-token itself, when set directly.
+	tokenEnvVar = "LYRICS_FETCH_APPLE_MUSIC_TOKEN"
+
+	// tokenFileEnvVar points at a file containing the media-user-token, as an alternative to tokenEnvVar.
+	tokenFileEnvVar = "LYRICS_FETCH_APPLE_MUSIC_TOKEN_FILE"
+
+	// developerTokenEnvVar holds the Apple Music developer (JWT) token used as the Authorization bearer.
+	developerTokenEnvVar = "LYRICS_FETCH_APPLE_MUSIC_DEVELOPER_TOKEN"
+)
+
+func init() {
+	Register("applemusic", func(cfg *config.Config) LyricsProvider {
+		return NewAppleMusic(cfg.AppleMusic.MediaUserTokenFile, cfg.AppleMusic.Enhanced)
+	})
+}
+
+// AppleMusic is the built-in LyricsProvider that fetches lyrics from Apple Music's catalog API and
+// converts the TTML response to LRC.
+type AppleMusic struct {
+	client *Client
+
+	// developerToken authenticates the app against the catalog API.
+	developerToken string
+
+	// mediaUserToken authenticates the request as a specific Apple Music subscriber; without it the
+	// lyrics endpoint returns 403.
+	mediaUserToken string
+
+	// storefronts records, per artist/track pair, the storefront a song ID was found in, and later
+	// lookups for the pair reuse it. Storefronts are not resolved per song: the first lookup always uses
+	// appleMusicDefaultStorefront, and the catalog search runs again on every Fetch. Fetch is called
+	// concurrently by the worker pool (see main.go), so access is guarded by storefrontsMu.
+	storefronts   map[string]string
+	storefrontsMu sync.Mutex
+
+	// enhanced switches the TTML->LRC conversion to emit Enhanced LRC word-level timing markers.
+	enhanced bool
+}
+
+// NewAppleMusic returns an AppleMusic provider configured from the environment: the developer token
+// from developerTokenEnvVar, and the media-user-token either directly from tokenEnvVar or read from
+// tokenFile (falling back to the file named by tokenFileEnvVar when tokenFile is empty, e.g. because
+// config.yaml didn't set applemusic.media_user_token_file). enhanced sets whether the TTML->LRC
+// conversion emits Enhanced LRC word-level timing markers (applemusic.enhanced / -enhanced).
+func NewAppleMusic(tokenFile string, enhanced bool) *AppleMusic {
+	return &AppleMusic{
+		client:         NewClient(),
+		developerToken: os.Getenv(developerTokenEnvVar),
+		mediaUserToken: mediaUserToken(tokenFile),
+		storefronts:    make(map[string]string),
+		enhanced:       enhanced,
+	}
+}
+
+// mediaUserToken resolves the subscriber token, preferring the literal token (tokenEnvVar) over a token
+// file, and preferring an explicitly configured tokenFile over tokenFileEnvVar.
+func mediaUserToken(tokenFile string) string {
+	if token := os.Getenv(tokenEnvVar); token != "" {
+		return token
+	}
+	if tokenFile == "" {
+		tokenFile = os.Getenv(tokenFileEnvVar)
+	}
+	if tokenFile == "" {
+		return ""
+	}
+	data, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// Name returns "applemusic".
+func (a *AppleMusic) Name() string { return "applemusic" }
+
+// catalogSearchResponse is the subset of the Apple Music catalog search response we need to resolve a
+// song ID from an ISRC or an artist/track pair.
+type catalogSearchResponse struct {
+	Results struct {
+		Songs struct {
+			Data []struct {
+				ID         string `json:"id"`
+				Attributes struct {
+					ISRC string `json:"isrc"`
+				} `json:"attributes"`
+			} `json:"data"`
+		} `json:"songs"`
+	} `json:"results"`
+}
+
+// lyricsResponse is the subset of the Apple Music lyrics response containing the TTML document.
+type lyricsResponse struct {
+	Data []struct {
+		Attributes struct {
+			TTML string `json:"ttml"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+// Fetch resolves the Apple Music song ID for the track (via ISRC when available, otherwise an
+// artist+track search), downloads its TTML lyrics and converts them to LRC.
+func (a *AppleMusic) Fetch(ctx context.Context, artist, album, track string, _ time.Duration) (Lyrics, error) {
+	if a.mediaUserToken == "" {
+		return Lyrics{}, fmt.Errorf("applemusic: %s (or %s) is not set; refresh the Apple Music media-user-token",
+			tokenEnvVar, tokenFileEnvVar)
+	}
+
+	storefront := a.storefrontFor(artist, track)
+
+	songID, err := a.resolveSongID(ctx, storefront, artist, album, track)
+	if err != nil {
+		return Lyrics{}, fmt.Errorf("applemusic: failed to resolve song ID for '%s - %s': %w", artist, track, err)
+	}
+	if songID == "" {
+		return Lyrics{}, nil
+	}
+	a.setStorefront(artist, track, storefront)
+
+	ttml, err := a.fetchTTML(ctx, storefront, songID)
+	if err != nil {
+		return Lyrics{}, fmt.Errorf("applemusic: failed to fetch lyrics for '%s - %s': %w", artist, track, err)
+	}
+	if ttml == "" {
+		return Lyrics{}, nil
+	}
+
+	lrc, err := ttmlToLRC(ttml, a.enhanced)
+	if err != nil {
+		return Lyrics{}, fmt.Errorf("applemusic: failed to convert TTML to LRC for '%s - %s': %w", artist, track, err)
+	}
+	return Lyrics{Synced: lrc}, nil
+}
+
+// storefrontFor returns the cached storefront for artist+track, or appleMusicDefaultStorefront if none
+// has been resolved yet.
+func (a *AppleMusic) storefrontFor(artist, track string) string {
+	a.storefrontsMu.Lock()
+	defer a.storefrontsMu.Unlock()
+	if storefront, ok := a.storefronts[artist+"|"+track]; ok {
+		return storefront
+	}
+	return appleMusicDefaultStorefront
+}
+
+// setStorefront caches storefront as the resolved storefront for artist+track.
+func (a *AppleMusic) setStorefront(artist, track, storefront string) {
+	a.storefrontsMu.Lock()
+	defer a.storefrontsMu.Unlock()
+	a.storefronts[artist+"|"+track] = storefront
+}
+
+// resolveSongID looks up the Apple Music catalog ID for a track, preferring an ISRC match read from the
+// audio file's own tags (when the source path is available via the context) and falling back to an
+// artist+track catalog search.
+func (a *AppleMusic) resolveSongID(ctx context.Context, storefront, artist, _, track string) (string, error) {
+	if isrc := a.isrcFromTags(ctx); isrc != "" {
+		id, err := a.searchCatalog(ctx, storefront, fmt.Sprintf("isrc:%s", isrc))
+		if err != nil {
+			return "", err
+		}
+		if id != "" {
+			return id, nil
+		}
+	}
+	return a.searchCatalog(ctx, storefront, fmt.Sprintf("%s %s", artist, track))
+}
+
+// isrcFromTags reads the ISRC out of the source audio file's tags, returning "" if unavailable (no
+// source path in context, unreadable file, or no ISRC tag present).
+func (a *AppleMusic) isrcFromTags(ctx context.Context) string {
+	path, ok := SourcePath(ctx)
+	if !ok {
+		return ""
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = file.Close() }()
+
+	meta, err := tag.ReadFrom(file)
+	if err != nil {
+		return ""
+	}
+	raw := meta.Raw()
+	for _, key := range []string{"TSRC", "isrc", "ISRC"} {
+		if isrc, ok := raw[key].(string); ok && isrc != "" {
+			return isrc
+		}
+	}
+	return ""
+}
+
+// searchCatalog queries the Apple Music catalog search endpoint and returns the first matching song ID,
+// or "" if nothing matched.
+func (a *AppleMusic) searchCatalog(ctx context.Context, storefront, term string) (string, error) {
+	query := url.Values{}
+	query.Set("term", term)
+	query.Set("types", "songs")
+	query.Set("limit", "1")
+
+	res := new(catalogSearchResponse)
+	endpoint := fmt.Sprintf("%s/%s/search", appleMusicAPI, storefront)
+	if _, err := a.client.GetWithTimeout(ctx, endpoint, res, query, a.headers(), appleMusicTimeout); err != nil {
+		return "", err
+	}
+	if len(res.Results.Songs.Data) == 0 {
+		return "", nil
+	}
+	return res.Results.Songs.Data[0].ID, nil
+}
+
+// fetchTTML downloads the raw TTML lyrics document for songID, or "" if Apple Music has none.
+func (a *AppleMusic) fetchTTML(ctx context.Context, storefront, songID string) (string, error) {
+	res := new(lyricsResponse)
+	endpoint := fmt.Sprintf("%s/%s/songs/%s/lyrics", appleMusicAPI, storefront, songID)
+	if _, err := a.client.GetWithTimeout(ctx, endpoint, res, nil, a.headers(), appleMusicTimeout); err != nil {
+		return "", err
+	}
+	if len(res.Data) == 0 {
+		return "", nil
+	}
+	return res.Data[0].Attributes.TTML, nil
+}
+
+// headers returns the Authorization and Media-User-Token headers required by every Apple Music API call.
+func (a *AppleMusic) headers() http.Header {
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer "+a.developerToken)
+	headers.Set("Media-User-Token", a.mediaUserToken)
+	return headers
+}
@@ -0,0 +1,80 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Client is a small HTTP client for JSON APIs, used by providers that fetch lyrics over HTTP (e.g.
+// LRCLIB, Apple Music). It centralizes query-string handling, header injection and timeouts so
+// individual providers stay focused on parsing the response body.
+type Client struct {
+	http *http.Client
+
+	// rps and burst configure a per-host token-bucket rate limit applied before every request; rps <= 0
+	// means unlimited, as for a Client from NewClient.
+	rps   float64
+	burst int
+}
+
+// NewClient returns a ready-to-use Client with a default *http.Client and no rate limiting.
+func NewClient() *Client {
+	return &Client{http: &http.Client{}}
+}
+
+// NewRateLimitedClient returns a Client that limits requests to rps per second (with the given burst)
+// per destination host, so providers that share a host (or retry the same one) can't exceed what the
+// upstream API allows.
+func NewRateLimitedClient(rps float64, burst int) *Client {
+	return &Client{http: &http.Client{}, rps: rps, burst: burst}
+}
+
+// GetWithTimeout performs a GET request against rawURL with query appended as the query string and
+// headers merged into the request, decoding the JSON response body into target. It returns the HTTP
+// status code alongside any error so callers can distinguish e.g. a 404 from a transport failure.
+func (c *Client) GetWithTimeout(ctx context.Context, rawURL string, target any, query url.Values,
+	headers http.Header, timeout time.Duration,
+) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	reqURL, err := url.Parse(rawURL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse request URL: %w", err)
+	}
+	reqURL.RawQuery = query.Encode()
+
+	if c.rps > 0 {
+		if err := limiterFor(reqURL.Host, c.rps, c.burst).Wait(ctx); err != nil {
+			return 0, fmt.Errorf("rate limiter: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+	for key, values := range headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to decode response body: %w", err)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,20 @@
+package providers
+
+import "context"
+
+// sourcePathKey is the context key used to thread the audio file's own path through to providers that
+// need it (currently Filesystem and AppleMusic), without widening the LyricsProvider.Fetch signature for
+// providers that have no use for it.
+type sourcePathKey struct{}
+
+// WithSourcePath returns a copy of ctx carrying path as the audio file being processed. The caller
+// (processFile) sets this before invoking the Chain; Filesystem and AppleMusic read it back via SourcePath.
+func WithSourcePath(ctx context.Context, path string) context.Context {
+	return context.WithValue(ctx, sourcePathKey{}, path)
+}
+
+// SourcePath returns the audio file path stored in ctx by WithSourcePath, and false if none was set.
+func SourcePath(ctx context.Context) (string, bool) {
+	path, ok := ctx.Value(sourcePathKey{}).(string)
+	return path, ok
+}
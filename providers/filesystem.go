@@ -0,0 +1,85 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dhowden/tag"
+	"github.com/wneessen/lyrics-fetch/config"
+)
+
+func init() {
+	Register("filesystem", func(*config.Config) LyricsProvider { return NewFilesystem() })
+}
+
+// Filesystem is the built-in LyricsProvider that looks for lyrics that are already available locally,
+// either as a sidecar ".lrc" file sharing the audio file's base name, or embedded in the audio file's
+// own tags. It relies on the source path being present in the context (see WithSourcePath); without it,
+// Fetch always reports no lyrics found.
+type Filesystem struct{}
+
+// NewFilesystem returns a Filesystem provider.
+func NewFilesystem() *Filesystem {
+	return &Filesystem{}
+}
+
+// Name returns "filesystem".
+func (f *Filesystem) Name() string { return "filesystem" }
+
+// Fetch first looks for a "<base>.lrc" file next to the audio file named by the context's source path,
+// then falls back to any lyrics already embedded in the file's own tags. The artist, album, track and
+// duration arguments are unused since the lookup is purely path-based.
+func (f *Filesystem) Fetch(ctx context.Context, _, _, _ string, _ time.Duration) (Lyrics, error) {
+	path, ok := SourcePath(ctx)
+	if !ok {
+		return Lyrics{}, nil
+	}
+
+	ext := filepath.Ext(path)
+	lrcPath := strings.TrimSuffix(path, ext) + ".lrc"
+	data, err := os.ReadFile(lrcPath)
+	switch {
+	case err == nil:
+		return Lyrics{Synced: string(data)}, nil
+	case !errors.Is(err, os.ErrNotExist):
+		return Lyrics{}, fmt.Errorf("failed to read %s: %w", lrcPath, err)
+	}
+
+	if plain := tagLyrics(path); plain != "" {
+		return Lyrics{Plain: plain}, nil
+	}
+	return Lyrics{}, nil
+}
+
+// tagLyrics reads any lyrics embedded in path's own tags, trying the raw tag keys dhowden/tag exposes
+// for the formats it supports: USLT for ID3v2 (MP3/AAC), LYRICS/UNSYNCEDLYRICS for Vorbis comments
+// (FLAC/OGG), and the "\xa9lyr" atom for MP4. It returns "" if the file can't be read or none of those
+// keys are present, the same tolerant behaviour as a missing sidecar file.
+func tagLyrics(path string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = file.Close() }()
+
+	meta, err := tag.ReadFrom(file)
+	if err != nil {
+		return ""
+	}
+	raw := meta.Raw()
+	if uslt, ok := raw["USLT"].(*tag.Comm); ok && uslt.Text != "" {
+		return uslt.Text
+	}
+	// Vorbis comment keys are lowercased by dhowden/tag.
+	for _, key := range []string{"lyrics", "unsyncedlyrics", "\xa9lyr"} {
+		if lyrics, ok := raw[key].(string); ok && lyrics != "" {
+			return lyrics
+		}
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+// Package providers defines the pluggable lyrics agent chain used by lyrics-fetch. A LyricsProvider
+// knows how to retrieve lyrics for a single track from one source (an API, the local filesystem, the
+// audio tags themselves, ...). A Chain tries a user-configured, ordered list of providers and returns
+// the first non-empty result, mirroring Navidrome's agent-chain approach to metadata lookups.
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wneessen/lyrics-fetch/config"
+)
+
+// Lyrics holds the lyrics retrieved from a provider, in whichever forms the provider was able to
+// supply. A provider that only has plain text leaves Synced empty, and vice versa.
+type Lyrics struct {
+	// Plain is the unsynchronised, plain-text lyrics, one line per line of the song.
+	Plain string
+
+	// Synced is the LRC-formatted, time-synchronised lyrics, when the provider has timing information.
+	Synced string
+
+	// Source identifies the provider the lyrics were retrieved from, for logging purposes.
+	Source string
+}
+
+// IsEmpty reports whether neither plain nor synced lyrics were found.
+func (l Lyrics) IsEmpty() bool {
+	return l.Plain == "" && l.Synced == ""
+}
+
+// HasSynced reports whether l carries time-synchronised lyrics, as opposed to plain text only.
+func (l Lyrics) HasSynced() bool {
+	return l.Synced != ""
+}
+
+// LyricsProvider is implemented by every lyrics source that can be registered into a Chain.
+type LyricsProvider interface {
+	// Name returns the short, lowercase identifier used to reference the provider in the -agents flag
+	// and config file (e.g. "lrclib", "filesystem").
+	Name() string
+
+	// Fetch retrieves lyrics for the given track. Implementations should return an empty Lyrics value
+	// and a nil error when the source was reachable but simply has no lyrics for this track, and a
+	// non-nil error only when the lookup itself failed (network error, bad credentials, ...), so that a
+	// Chain can tell "try the next provider" apart from "something is broken".
+	Fetch(ctx context.Context, artist, album, track string, duration time.Duration) (Lyrics, error)
+}
+
+// factories holds the providers registered via Register, keyed by their Name(). Built-in providers
+// register themselves from an init function in their own file so that new providers can be added by
+// importing their package for side effects, without touching the chain itself. Each factory receives
+// the effective config so it can pick up its own settings (endpoint, timeout, token file, ...).
+var factories = map[string]func(cfg *config.Config) LyricsProvider{}
+
+// Register adds a provider factory under name. It is meant to be called from a provider's init
+// function; a duplicate name panics since it indicates a programming error, not a runtime condition.
+func Register(name string, factory func(cfg *config.Config) LyricsProvider) {
+	if _, exists := factories[name]; exists {
+		panic(fmt.Sprintf("providers: provider %q already registered", name))
+	}
+	factories[name] = factory
+}
+
+// Chain tries a list of LyricsProvider in order and returns the first non-empty result.
+type Chain struct {
+	providers []LyricsProvider
+}
+
+// NewChain builds a Chain from the given, ordered list of provider names (as set by cfg.Agents). It
+// returns an error if any name is not a registered provider.
+func NewChain(names []string, cfg *config.Config) (*Chain, error) {
+	chain := &Chain{providers: make([]LyricsProvider, 0, len(names))}
+	for _, name := range names {
+		factory, ok := factories[name]
+		if !ok {
+			return nil, fmt.Errorf("providers: unknown provider %q", name)
+		}
+		chain.providers = append(chain.providers, factory(cfg))
+	}
+	return chain, nil
+}
+
+// Fetch tries each provider in the chain's configured order and returns the first non-empty Lyrics. A
+// provider that errors is skipped; its error is dropped if a later provider has lyrics. When every
+// provider has been tried without a hit, Fetch returns an empty Lyrics together with the joined errors
+// of the providers that failed, which is nil only when none of them failed.
+func (c *Chain) Fetch(ctx context.Context, artist, album, track string, duration time.Duration) (Lyrics, error) {
+	var errs []error
+	for _, provider := range c.providers {
+		lyrics, err := provider.Fetch(ctx, artist, album, track, duration)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", provider.Name(), err))
+			continue
+		}
+		if !lyrics.IsEmpty() {
+			lyrics.Source = provider.Name()
+			return lyrics, nil
+		}
+	}
+	return Lyrics{}, errors.Join(errs...)
+}
@@ -0,0 +1,24 @@
+package providers
+
+import (
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// hostLimiters holds one *rate.Limiter per upstream host, shared across every Client so that, for
+// example, an LRCLIB-configured rate limit is respected no matter how many concurrent worker goroutines
+// (or Client instances) are hitting lrclib.net at once.
+var hostLimiters sync.Map // host string -> *rate.Limiter
+
+// limiterFor returns the shared rate.Limiter for host, creating one with the given rate/burst the first
+// time host is seen. Later calls for the same host ignore rps/burst and return the existing limiter,
+// since the limit is a property of the host, not of whichever provider happens to ask first.
+func limiterFor(host string, rps float64, burst int) *rate.Limiter {
+	if existing, ok := hostLimiters.Load(host); ok {
+		return existing.(*rate.Limiter)
+	}
+	limiter := rate.NewLimiter(rate.Limit(rps), burst)
+	actual, _ := hostLimiters.LoadOrStore(host, limiter)
+	return actual.(*rate.Limiter)
+}
@@ -0,0 +1,43 @@
+package providers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SynthesizeLRC builds a crude synchronised lyrics file out of plain, unsynchronised lyrics, for use
+// when a provider only has PlainLyrics and the caller has opted in via -allow-plain. Each non-empty line
+// gets a "[mm:ss.xx]" tag: evenly spread across duration when it's known, or "[00:00.00]" for every line
+// when it isn't.
+func SynthesizeLRC(plain string, duration time.Duration) string {
+	var lines []string
+	for _, line := range strings.Split(plain, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+
+	var step time.Duration
+	if duration > 0 {
+		step = duration / time.Duration(len(lines))
+	}
+
+	var b strings.Builder
+	for i, line := range lines {
+		fmt.Fprintf(&b, "%s%s\n", lrcDuration(time.Duration(i)*step), line)
+	}
+	return b.String()
+}
+
+// lrcDuration renders d as an LRC "[mm:ss.xx]" tag.
+func lrcDuration(d time.Duration) string {
+	wholeSeconds := int(d / time.Second)
+	minutes := wholeSeconds / 60
+	seconds := wholeSeconds % 60
+	centiseconds := int((d % time.Second) / (10 * time.Millisecond))
+	return fmt.Sprintf("[%02d:%02d.%02d]", minutes, seconds, centiseconds)
+}
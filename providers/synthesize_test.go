@@ -0,0 +1,57 @@
+package providers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSynthesizeLRC(t *testing.T) {
+	tests := []struct {
+		name     string
+		plain    string
+		duration time.Duration
+		want     string
+	}{
+		{
+			name:  "zero duration stamps every line at 00:00.00",
+			plain: "line one\nline two\nline three",
+			want:  "[00:00.00]line one\n[00:00.00]line two\n[00:00.00]line three\n",
+		},
+		{
+			name:     "single line uses the start timestamp regardless of duration",
+			plain:    "only line",
+			duration: 3 * time.Minute,
+			want:     "[00:00.00]only line\n",
+		},
+		{
+			name:     "duration spreads timestamps evenly across lines",
+			plain:    "one\ntwo\nthree\nfour",
+			duration: 4 * time.Second,
+			want:     "[00:00.00]one\n[00:01.00]two\n[00:02.00]three\n[00:03.00]four\n",
+		},
+		{
+			name:  "blank lines are dropped",
+			plain: "one\n\n  \ntwo",
+			want:  "[00:00.00]one\n[00:00.00]two\n",
+		},
+		{
+			name:  "all blank input yields no output",
+			plain: "\n  \n",
+			want:  "",
+		},
+		{
+			name:  "empty input yields no output",
+			plain: "",
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SynthesizeLRC(tt.plain, tt.duration)
+			if got != tt.want {
+				t.Errorf("SynthesizeLRC(%q, %v) = %q, want %q", tt.plain, tt.duration, got, tt.want)
+			}
+		})
+	}
+}
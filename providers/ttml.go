@@ -0,0 +1,137 @@
+package providers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ttmlDocument is the minimal subset of the TTML (Timed Text Markup Language) schema Apple Music
+// returns: a <body> of <div> sections, each containing timed <p> lines, each optionally broken into
+// word-level <span> children.
+type ttmlDocument struct {
+	Body struct {
+		Divs []ttmlDiv `xml:"div"`
+	} `xml:"body"`
+}
+
+type ttmlDiv struct {
+	Paragraphs []ttmlParagraph `xml:"p"`
+}
+
+type ttmlParagraph struct {
+	Begin string     `xml:"begin,attr"`
+	Spans []ttmlSpan `xml:"span"`
+	Text  string     `xml:",chardata"`
+}
+
+type ttmlSpan struct {
+	Begin string `xml:"begin,attr"`
+	End   string `xml:"end,attr"`
+	Text  string `xml:",chardata"`
+}
+
+// ttmlToLRC converts a TTML lyrics document into LRC text. Each <p> becomes one `[mm:ss.xx]text` line;
+// a <p> with no `begin` attribute is skipped since LRC has no way to represent an untimed line. When
+// enhanced is true and the <p> carries word-level <span> timing, the line instead uses Enhanced LRC
+// syntax with an inline `<mm:ss.xx>` marker before each word.
+func ttmlToLRC(doc string, enhanced bool) (string, error) {
+	var ttml ttmlDocument
+	if err := xml.Unmarshal([]byte(doc), &ttml); err != nil {
+		return "", fmt.Errorf("failed to parse TTML document: %w", err)
+	}
+
+	var lines []string
+	for _, div := range ttml.Body.Divs {
+		for _, p := range div.Paragraphs {
+			if p.Begin == "" {
+				continue
+			}
+			line, err := ttmlLine(p, enhanced)
+			if err != nil {
+				return "", err
+			}
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// ttmlLine renders a single <p> as one LRC line.
+func ttmlLine(p ttmlParagraph, enhanced bool) (string, error) {
+	tag, err := lrcTimestamp(p.Begin)
+	if err != nil {
+		return "", err
+	}
+
+	if !enhanced || len(p.Spans) == 0 {
+		return tag + plainText(p), nil
+	}
+
+	var text strings.Builder
+	text.WriteString(tag)
+	for i, span := range p.Spans {
+		spanTag, err := lrcTimestamp(span.Begin)
+		if err != nil {
+			return "", err
+		}
+		if i > 0 {
+			text.WriteString(" ")
+		}
+		text.WriteString("<" + spanTag[1:len(spanTag)-1] + ">")
+		text.WriteString(strings.TrimSpace(span.Text))
+	}
+	return text.String(), nil
+}
+
+// plainText returns the line's text, preferring the concatenated span text (when word-level timing was
+// present but enhanced output was not requested) over the <p>'s own character data.
+func plainText(p ttmlParagraph) string {
+	if len(p.Spans) == 0 {
+		return strings.TrimSpace(p.Text)
+	}
+	words := make([]string, 0, len(p.Spans))
+	for _, span := range p.Spans {
+		if text := strings.TrimSpace(span.Text); text != "" {
+			words = append(words, text)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// lrcTimestamp converts a TTML "MM:SS.mmm" (or "HH:MM:SS.mmm") begin attribute into an LRC
+// "[mm:ss.xx]" tag, normalizing milliseconds down to two-digit centiseconds.
+func lrcTimestamp(begin string) (string, error) {
+	parts := strings.Split(begin, ":")
+	if len(parts) < 2 {
+		return "", fmt.Errorf("invalid TTML timestamp: %q", begin)
+	}
+	secondsPart := parts[len(parts)-1]
+	minutesPart := parts[len(parts)-2]
+
+	minutes, err := strconv.Atoi(minutesPart)
+	if err != nil {
+		return "", fmt.Errorf("invalid TTML timestamp minutes: %q", begin)
+	}
+	// Fold any hours component into minutes so the LRC tag stays "mm:ss.xx".
+	if len(parts) == 3 {
+		hours, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return "", fmt.Errorf("invalid TTML timestamp hours: %q", begin)
+		}
+		minutes += hours * 60
+	}
+
+	seconds, err := strconv.ParseFloat(secondsPart, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid TTML timestamp seconds: %q", begin)
+	}
+
+	duration := time.Duration(seconds * float64(time.Second))
+	wholeSeconds := int(duration / time.Second)
+	centiseconds := int((duration % time.Second) / (10 * time.Millisecond))
+
+	return fmt.Sprintf("[%02d:%02d.%02d]", minutes, wholeSeconds, centiseconds), nil
+}
@@ -0,0 +1,110 @@
+package providers
+
+import "testing"
+
+func TestLRCTimestamp(t *testing.T) {
+	tests := []struct {
+		name    string
+		begin   string
+		want    string
+		wantErr bool
+	}{
+		{name: "minutes and seconds", begin: "01:23.456", want: "[01:23.45]"},
+		{name: "hours fold into minutes", begin: "01:02:03.456", want: "[62:03.45]"},
+		{name: "zero", begin: "00:00.000", want: "[00:00.00]"},
+		{name: "missing colon", begin: "1.5", wantErr: true},
+		{name: "empty", begin: "", wantErr: true},
+		{name: "non-numeric minutes", begin: "ab:01.000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lrcTimestamp(tt.begin)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("lrcTimestamp(%q) = %q, want error", tt.begin, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("lrcTimestamp(%q) returned unexpected error: %v", tt.begin, err)
+			}
+			if got != tt.want {
+				t.Errorf("lrcTimestamp(%q) = %q, want %q", tt.begin, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTTMLToLRC(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		enhanced bool
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "single timed line",
+			doc:  `<tt><body><div><p begin="00:01.000">Hello there</p></div></body></tt>`,
+			want: "[00:01.00]Hello there",
+		},
+		{
+			name: "untimed line is skipped",
+			doc: `<tt><body><div>
+				<p begin="00:01.000">Kept</p>
+				<p>Dropped</p>
+			</div></body></tt>`,
+			want: "[00:01.00]Kept",
+		},
+		{
+			name: "standard LRC concatenates span text",
+			doc: `<tt><body><div><p begin="00:01.000">` +
+				`<span begin="00:01.000" end="00:01.500">Hello</span>` +
+				`<span begin="00:01.500" end="00:02.000">there</span>` +
+				`</p></div></body></tt>`,
+			enhanced: false,
+			want:     "[00:01.00]Hello there",
+		},
+		{
+			name: "enhanced emits inline word timestamps",
+			doc: `<tt><body><div><p begin="00:01.000">` +
+				`<span begin="00:01.000" end="00:01.500">Hello</span>` +
+				`<span begin="00:01.500" end="00:02.000">there</span>` +
+				`</p></div></body></tt>`,
+			enhanced: true,
+			want:     "[00:01.00]<00:01.00>Hello <00:01.50>there",
+		},
+		{
+			name: "span with missing begin aborts the whole conversion",
+			doc: `<tt><body><div><p begin="00:01.000">` +
+				`<span end="00:01.500">Hello</span>` +
+				`</p></div></body></tt>`,
+			enhanced: true,
+			wantErr:  true,
+		},
+		{
+			name:    "malformed XML",
+			doc:     `<tt><body>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ttmlToLRC(tt.doc, tt.enhanced)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ttmlToLRC() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ttmlToLRC() returned unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ttmlToLRC() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}